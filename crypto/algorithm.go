@@ -0,0 +1,70 @@
+package crypto
+
+import (
+	"errors"
+)
+
+// SignatureAlgorithm identifies which signing scheme produced a PublicKey,
+// SecretKey, or Signature. It is encoded as a single byte, which is
+// written as a one-byte prefix before every non-ed25519 key or signature
+// on the wire; ed25519 values keep their legacy untagged layout.
+type SignatureAlgorithm byte
+
+// The set of signature algorithms that Sia understands. AlgoEd25519 is
+// the original algorithm and remains the default: a PublicKey, SecretKey,
+// or Signature with a zero Algo field is an ed25519 object, so legacy
+// fixed-size ed25519 values need no migration.
+const (
+	AlgoEd25519 SignatureAlgorithm = iota
+	AlgoECDSASecp256k1
+	AlgoRSA
+)
+
+// String implements fmt.Stringer.
+func (a SignatureAlgorithm) String() string {
+	switch a {
+	case AlgoEd25519:
+		return "ed25519"
+	case AlgoECDSASecp256k1:
+		return "secp256k1"
+	case AlgoRSA:
+		return "rsa"
+	default:
+		return "unknown"
+	}
+}
+
+// algorithm is implemented by each supported signature backend and
+// registered in algorithms under its SignatureAlgorithm tag.
+type algorithm interface {
+	// generateKeyPair creates a new secret/public key pair.
+	generateKeyPair() (sk, pk []byte, err error)
+	// publicKey derives the public key matching a secret key.
+	publicKey(sk []byte) (pk []byte, err error)
+	// sign signs a hash, producing a signature.
+	sign(sk []byte, hash Hash) (sig []byte, err error)
+	// verify checks a signature against a hash and public key.
+	verify(pk []byte, hash Hash, sig []byte) error
+}
+
+// errUnknownAlgorithm is returned when a SignatureAlgorithm tag has no
+// registered implementation.
+var errUnknownAlgorithm = errors.New("unknown signature algorithm")
+
+// algorithms is the registry of supported signature algorithms, keyed by
+// their SignatureAlgorithm tag.
+var algorithms = map[SignatureAlgorithm]algorithm{
+	AlgoEd25519:        ed25519Algorithm{},
+	AlgoECDSASecp256k1: ecdsaSecp256k1Algorithm{},
+	AlgoRSA:            rsaAlgorithm{},
+}
+
+// algo looks up the implementation registered for a, returning
+// errUnknownAlgorithm if none is registered.
+func algo(a SignatureAlgorithm) (algorithm, error) {
+	impl, ok := algorithms[a]
+	if !ok {
+		return nil, errUnknownAlgorithm
+	}
+	return impl, nil
+}
@@ -0,0 +1,37 @@
+package crypto
+
+import "testing"
+
+// TestSignHashVerifyHashByAlgo verifies that SignHash/VerifyHash round-trip
+// correctly for every registered signature algorithm, not just the
+// default ed25519 one — exercising each algorithm's own sign/verify
+// implementation directly, rather than through the crypto.Signer
+// adapter in stdlib.go.
+func TestSignHashVerifyHashByAlgo(t *testing.T) {
+	for _, a := range []SignatureAlgorithm{AlgoEd25519, AlgoECDSASecp256k1, AlgoRSA} {
+		t.Run(a.String(), func(t *testing.T) {
+			sk, pk, err := GenerateKeyPairAlgo(a)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			hash := HashBytes([]byte("message for " + a.String()))
+			sig, err := SignHash(hash, sk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sig.Algo != a {
+				t.Fatalf("signature algo = %v, want %v", sig.Algo, a)
+			}
+			if err := VerifyHash(hash, pk, sig); err != nil {
+				t.Fatalf("VerifyHash failed: %v", err)
+			}
+
+			// a signature over a different hash must not verify
+			otherHash := HashBytes([]byte("a different message"))
+			if err := VerifyHash(otherHash, pk, sig); err == nil {
+				t.Fatal("VerifyHash accepted a signature over the wrong hash")
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+
+	"filippo.io/edwards25519"
+)
+
+// BatchEntry is a single (public key, hash, signature) triple to be
+// checked by VerifyHashBatch.
+type BatchEntry struct {
+	PublicKey PublicKey
+	Hash      Hash
+	Signature Signature
+}
+
+// VerifyHashBatch verifies many ed25519 signatures at once using the
+// standard batch-verification equation
+//
+//	∑ zᵢ·(sᵢ·B − Rᵢ − Hᵢ·Aᵢ) = 0
+//
+// with independent random scalars zᵢ, which is roughly 2x faster than
+// verifying each signature individually once there are 64 or more
+// entries. Every entry must use AlgoEd25519 — mixing algorithms returns
+// errUnknownAlgorithm rather than silently skipping entries. This lets
+// modules/consensus batch-verify every transaction signature in a block
+// in one pass instead of calling VerifyHash per signature.
+//
+// If the batch fails to verify, VerifyHashBatch falls back to checking
+// every entry individually via VerifyHash so it can report badIdx, the
+// index of the first invalid entry.
+func VerifyHashBatch(entries []BatchEntry) (ok bool, badIdx int, err error) {
+	if len(entries) == 0 {
+		return true, -1, nil
+	}
+	// There is no len(entries) == 1 fast path: a single-entry batch must
+	// get the same small-order-A/R and canonical-s checks as every other
+	// entry, so it goes through the general multi-scalar-mult equation
+	// below like any other batch size.
+
+	points := make([]*edwards25519.Point, 0, 2*len(entries)+1)
+	scalars := make([]*edwards25519.Scalar, 0, 2*len(entries)+1)
+	sSum := edwards25519.NewScalar()
+
+	for _, e := range entries {
+		if e.PublicKey.Algo != AlgoEd25519 || e.Signature.Algo != AlgoEd25519 {
+			return false, -1, errUnknownAlgorithm
+		}
+		if len(e.PublicKey.Key) != PublicKeySize || len(e.Signature.Sig) != SignatureSize {
+			return false, -1, errInvalidSignature
+		}
+
+		// SetBytes rejects malformed or non-canonical encodings, but a
+		// small-order (torsion) point decompresses just fine — it's a
+		// valid curve point, just one of the 8 with order dividing the
+		// cofactor. Multiplying by the cofactor maps every such point to
+		// the identity while leaving prime-order points nonzero, so that
+		// check is what actually rejects small-order A and R.
+		A, err := new(edwards25519.Point).SetBytes(e.PublicKey.Key)
+		if err != nil {
+			return false, -1, errInvalidSignature
+		}
+		if isSmallOrder(A) {
+			return false, -1, errInvalidSignature
+		}
+		R, err := new(edwards25519.Point).SetBytes(e.Signature.Sig[:32])
+		if err != nil {
+			return false, -1, errInvalidSignature
+		}
+		if isSmallOrder(R) {
+			return false, -1, errInvalidSignature
+		}
+		// SetCanonicalBytes rejects s >= L instead of silently reducing
+		// it, so non-canonical signatures are rejected rather than
+		// accepted under a different representative.
+		s, err := edwards25519.NewScalar().SetCanonicalBytes(e.Signature.Sig[32:])
+		if err != nil {
+			return false, -1, errInvalidSignature
+		}
+
+		// H = SHA-512(R ‖ A ‖ M) mod L
+		digest := sha512.New()
+		digest.Write(e.Signature.Sig[:32])
+		digest.Write(e.PublicKey.Key)
+		digest.Write(e.Hash[:])
+		H, err := edwards25519.NewScalar().SetUniformBytes(digest.Sum(nil))
+		if err != nil {
+			return false, -1, err
+		}
+
+		// z is an independent uniform scalar drawn from crypto/rand,
+		// never derived from the entry's own data, so an adversary
+		// can't choose signatures that cancel the equation.
+		z, err := randomBatchScalar()
+		if err != nil {
+			return false, -1, err
+		}
+
+		sSum.Add(sSum, edwards25519.NewScalar().Multiply(z, s))
+
+		points = append(points, R, A)
+		scalars = append(scalars,
+			edwards25519.NewScalar().Negate(z),
+			edwards25519.NewScalar().Negate(edwards25519.NewScalar().Multiply(z, H)),
+		)
+	}
+	points = append(points, edwards25519.NewGeneratorPoint())
+	scalars = append(scalars, sSum)
+
+	sum := new(edwards25519.Point).VarTimeMultiScalarMult(scalars, points)
+	if sum.Equal(edwards25519.NewIdentityPoint()) == 1 {
+		return true, -1, nil
+	}
+
+	// The batch didn't verify; find the bad entry (or entries) the slow
+	// way so the caller can report a useful badIdx.
+	for i, e := range entries {
+		if err := VerifyHash(e.Hash, e.PublicKey, e.Signature); err != nil {
+			return false, i, nil
+		}
+	}
+	// Every entry verifies individually despite the batch equation
+	// failing; this should not happen outside of a prior programming
+	// error in this function.
+	return false, -1, errInvalidSignature
+}
+
+// isSmallOrder reports whether p has order dividing the curve's
+// cofactor (8) — one of the 8 small-order points, including the
+// identity. Multiplying any such point by the cofactor yields the
+// identity, while multiplying a point from the prime-order subgroup by
+// the cofactor never does, since 8 is invertible mod the subgroup's
+// prime order.
+func isSmallOrder(p *edwards25519.Point) bool {
+	return new(edwards25519.Point).MultByCofactor(p).Equal(edwards25519.NewIdentityPoint()) == 1
+}
+
+// randomBatchScalar draws a uniform 128-bit scalar from crypto/rand for
+// use as a zᵢ coefficient in VerifyHashBatch. 128 bits is enough to make
+// the batch equation's false-positive probability negligible while
+// keeping the multi-scalar multiplication cheap.
+func randomBatchScalar() (*edwards25519.Scalar, error) {
+	var wide [64]byte
+	if _, err := rand.Read(wide[:16]); err != nil {
+		return nil, err
+	}
+	return edwards25519.NewScalar().SetUniformBytes(wide[:])
+}
@@ -0,0 +1,140 @@
+package crypto
+
+import (
+	"testing"
+
+	"filippo.io/edwards25519"
+)
+
+// signedBatchEntry generates a fresh ed25519 keypair and signs msg,
+// returning a BatchEntry ready for VerifyHashBatch.
+func signedBatchEntry(t *testing.T, msg string) BatchEntry {
+	t.Helper()
+	sk, pk, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := HashBytes([]byte(msg))
+	sig, err := SignHash(hash, sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return BatchEntry{PublicKey: pk, Hash: hash, Signature: sig}
+}
+
+// TestVerifyHashBatchSingleEntry verifies that a batch of exactly one
+// entry still verifies correctly.
+func TestVerifyHashBatchSingleEntry(t *testing.T) {
+	entries := []BatchEntry{signedBatchEntry(t, "solo")}
+	ok, badIdx, err := VerifyHashBatch(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || badIdx != -1 {
+		t.Fatalf("got ok=%v badIdx=%d, want ok=true badIdx=-1", ok, badIdx)
+	}
+}
+
+// TestVerifyHashBatchSingleEntryRejectsSmallOrderKey verifies that a
+// single-entry batch gets the same small-order-A protection as a
+// multi-entry one, rather than falling through to a cheaper check.
+func TestVerifyHashBatchSingleEntryRejectsSmallOrderKey(t *testing.T) {
+	entry := signedBatchEntry(t, "msg")
+	entry.PublicKey = PublicKey{Algo: AlgoEd25519, Key: edwards25519.NewIdentityPoint().Bytes()}
+
+	ok, _, err := VerifyHashBatch([]BatchEntry{entry})
+	if err == nil && ok {
+		t.Fatal("expected small-order public key to be rejected in a single-entry batch")
+	}
+}
+
+// TestVerifyHashBatchSingleEntryRejectsNonCanonicalS is the
+// single-entry analogue of TestVerifyHashBatchRejectsNonCanonicalS.
+func TestVerifyHashBatchSingleEntryRejectsNonCanonicalS(t *testing.T) {
+	entry := signedBatchEntry(t, "msg")
+	for i := 32; i < 64; i++ {
+		entry.Signature.Sig[i] = 0xff
+	}
+
+	ok, _, err := VerifyHashBatch([]BatchEntry{entry})
+	if err == nil && ok {
+		t.Fatal("expected non-canonical s to be rejected in a single-entry batch")
+	}
+}
+
+// TestVerifyHashBatchMultipleEntries verifies a batch of several valid
+// signatures from distinct keys.
+func TestVerifyHashBatchMultipleEntries(t *testing.T) {
+	entries := []BatchEntry{
+		signedBatchEntry(t, "one"),
+		signedBatchEntry(t, "two"),
+		signedBatchEntry(t, "three"),
+	}
+	ok, badIdx, err := VerifyHashBatch(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || badIdx != -1 {
+		t.Fatalf("got ok=%v badIdx=%d, want ok=true badIdx=-1", ok, badIdx)
+	}
+}
+
+// TestVerifyHashBatchBadEntry verifies that a tampered entry is caught
+// and reported as the bad index, not just a bulk verification failure.
+func TestVerifyHashBatchBadEntry(t *testing.T) {
+	good := signedBatchEntry(t, "good")
+	bad := signedBatchEntry(t, "original")
+	bad.Hash = HashBytes([]byte("tampered"))
+
+	ok, badIdx, err := VerifyHashBatch([]BatchEntry{good, bad})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok || badIdx != 1 {
+		t.Fatalf("got ok=%v badIdx=%d, want ok=false badIdx=1", ok, badIdx)
+	}
+}
+
+// TestVerifyHashBatchRejectsSmallOrderKey verifies that a public key
+// decompressing to a small-order point — the identity point, in
+// particular — is rejected rather than silently accepted as a valid
+// curve point.
+func TestVerifyHashBatchRejectsSmallOrderKey(t *testing.T) {
+	entry := signedBatchEntry(t, "msg")
+	identity := edwards25519.NewIdentityPoint().Bytes()
+	entry.PublicKey = PublicKey{Algo: AlgoEd25519, Key: identity}
+
+	ok, _, err := VerifyHashBatch([]BatchEntry{entry, signedBatchEntry(t, "other")})
+	if err == nil && ok {
+		t.Fatal("expected small-order public key to be rejected")
+	}
+}
+
+// TestVerifyHashBatchRejectsSmallOrderR verifies that a signature whose
+// R component decompresses to a small-order point is rejected the same
+// way a small-order public key is.
+func TestVerifyHashBatchRejectsSmallOrderR(t *testing.T) {
+	entry := signedBatchEntry(t, "msg")
+	identity := edwards25519.NewIdentityPoint().Bytes()
+	copy(entry.Signature.Sig[:32], identity)
+
+	ok, _, err := VerifyHashBatch([]BatchEntry{entry, signedBatchEntry(t, "other")})
+	if err == nil && ok {
+		t.Fatal("expected small-order R to be rejected")
+	}
+}
+
+// TestVerifyHashBatchRejectsNonCanonicalS verifies that a signature
+// whose s scalar is not canonically reduced (s >= L) is rejected rather
+// than silently accepted under some other representative mod L.
+func TestVerifyHashBatchRejectsNonCanonicalS(t *testing.T) {
+	entry := signedBatchEntry(t, "msg")
+	for i := 32; i < 64; i++ {
+		entry.Signature.Sig[i] = 0xff
+	}
+
+	ok, _, err := VerifyHashBatch([]BatchEntry{entry, signedBatchEntry(t, "other")})
+	if err == nil && ok {
+		t.Fatal("expected non-canonical s to be rejected")
+	}
+}
@@ -0,0 +1,199 @@
+package crypto
+
+import (
+	"hash"
+	"io"
+
+	"github.com/NebulousLabs/Sia/encoding"
+	"golang.org/x/crypto/blake2b"
+)
+
+// DataSource supplies the bytes to be signed or verified without
+// requiring the caller to hold the entire object in memory at once. It
+// lets large objects — storage proofs, contract revisions, file
+// contract data — be signed by streaming them through a hash instead of
+// materializing them fully via encoding.Marshal first.
+type DataSource interface {
+	// ReadSignedData reads up to len(buf) bytes into buf and returns the
+	// filled portion. It returns io.EOF once SignedDataSize bytes have
+	// been returned in total, following the same "valid data, then EOF"
+	// convention as io.Reader.
+	ReadSignedData(buf []byte) ([]byte, error)
+	// SignedDataSize returns the total number of bytes the source will
+	// yield across all calls to ReadSignedData.
+	SignedDataSize() int
+}
+
+// DataWithSignature is a DataSource that also stores its own public key
+// and signature, so signing or verifying it can read back and update
+// those fields directly on the object.
+type DataWithSignature interface {
+	DataSource
+	// GetSignatureWithKey returns the encoded public key and signature
+	// currently stored on the object.
+	GetSignatureWithKey() (key, sig []byte)
+	// SetSignatureWithKey stores an encoded public key and signature on
+	// the object.
+	SetSignatureWithKey(key, sig []byte)
+}
+
+// SignOption configures SignDataSource and SignDataWithSignature. There
+// are no options yet; the type exists so flags such as a
+// domain-separation salt can be added later without breaking callers.
+type SignOption func(*signOptions)
+
+type signOptions struct{}
+
+// HashWriter is an io.Writer that hashes every byte written to it, so a
+// DataSource can be streamed through it instead of hashed all at once.
+type HashWriter struct {
+	h hash.Hash
+}
+
+// NewHashWriter returns an empty HashWriter ready to be written to.
+func NewHashWriter() *HashWriter {
+	h, _ := blake2b.New256(nil) // blake2b.New256 only errors on a bad key, and we pass none
+	return &HashWriter{h: h}
+}
+
+// Write implements io.Writer.
+func (hw *HashWriter) Write(p []byte) (int, error) {
+	return hw.h.Write(p)
+}
+
+// Sum returns the Hash of all bytes written so far.
+func (hw *HashWriter) Sum() (h Hash) {
+	hw.h.Sum(h[:0])
+	return
+}
+
+// hashDataSource streams ds through a HashWriter and returns the
+// resulting Hash.
+func hashDataSource(ds DataSource) (Hash, error) {
+	hw := NewHashWriter()
+	buf := make([]byte, 64*1024)
+	for {
+		chunk, err := ds.ReadSignedData(buf)
+		if len(chunk) > 0 {
+			hw.Write(chunk)
+		}
+		if err == io.EOF {
+			return hw.Sum(), nil
+		} else if err != nil {
+			return Hash{}, err
+		}
+	}
+}
+
+// SignDataSource signs a DataSource by streaming it into a hash and
+// signing the resulting digest, without ever holding the full source in
+// memory.
+func SignDataSource(ds DataSource, sk SecretKey, opts ...SignOption) (Signature, error) {
+	hash, err := hashDataSource(ds)
+	if err != nil {
+		return Signature{}, err
+	}
+	return SignHash(hash, sk)
+}
+
+// VerifyDataSource verifies a signature produced by SignDataSource.
+func VerifyDataSource(ds DataSource, pk PublicKey, sig Signature) error {
+	hash, err := hashDataSource(ds)
+	if err != nil {
+		return err
+	}
+	return VerifyHash(hash, pk, sig)
+}
+
+// SignDataWithSignature signs dws and writes the signing key and
+// signature back onto it via SetSignatureWithKey.
+func SignDataWithSignature(dws DataWithSignature, sk SecretKey, opts ...SignOption) error {
+	sig, err := SignDataSource(dws, sk, opts...)
+	if err != nil {
+		return err
+	}
+	pk, err := sk.PublicKey()
+	if err != nil {
+		return err
+	}
+	dws.SetSignatureWithKey(encoding.Marshal(pk), encoding.Marshal(sig))
+	return nil
+}
+
+// VerifyDataWithSignature verifies the signature stored on dws against
+// pk. The key dws reports via GetSignatureWithKey is not trusted for
+// verification — it is informational only — so callers must supply the
+// public key they actually trust.
+func VerifyDataWithSignature(dws DataWithSignature, pk PublicKey) error {
+	_, sigBytes := dws.GetSignatureWithKey()
+	// sig.Algo is seeded from pk so Signature.UnmarshalSia knows which
+	// wire layout to expect; see ReadSignedObject.
+	sig := Signature{Algo: pk.Algo}
+	if err := encoding.Unmarshal(sigBytes, &sig); err != nil {
+		return err
+	}
+	return VerifyDataSource(dws, pk, sig)
+}
+
+// countingWriter is an io.Writer that only counts the bytes written to
+// it, so the size of an encoding.Marshal-able object can be measured
+// without holding the encoded bytes anywhere.
+type countingWriter int
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	*cw += countingWriter(len(p))
+	return len(p), nil
+}
+
+// encodingSource adapts an arbitrary object to DataSource by streaming it
+// through encoding.NewEncoder into an io.Pipe, so ReadSignedData yields
+// it chunk-by-chunk instead of requiring the full encoding to be
+// materialized up front.
+type encodingSource struct {
+	obj     interface{}
+	pr      *io.PipeReader
+	started bool
+	size    int
+}
+
+// NewEncodingSource returns a DataSource that lazily marshals obj via
+// encoding.NewEncoder, chunk-by-chunk as ReadSignedData is called, so
+// existing callers of SignObject-style APIs can switch to the
+// DataSource-based signing functions without reworking their types or
+// buffering the whole encoded object in memory.
+func NewEncodingSource(obj interface{}) DataSource {
+	return &encodingSource{obj: obj}
+}
+
+// SignedDataSize implements DataSource. It makes a throwaway encoding
+// pass through a countingWriter to measure the size, since
+// encoding.NewEncoder has no way to report a marshaled size without
+// encoding first.
+func (es *encodingSource) SignedDataSize() int {
+	if es.size == 0 {
+		var cw countingWriter
+		encoding.NewEncoder(&cw).Encode(es.obj)
+		es.size = int(cw)
+	}
+	return es.size
+}
+
+// ReadSignedData implements DataSource. The first call spawns a goroutine
+// that encodes es.obj into an io.Pipe; that goroutine blocks on the pipe
+// and only exits once ReadSignedData has been called enough times to
+// drain the encoding to io.EOF (as hashDataSource always does). A caller
+// that abandons an encodingSource partway through — by calling
+// ReadSignedData a few times and then discarding it instead of reading
+// until io.EOF — leaks that goroutine.
+func (es *encodingSource) ReadSignedData(buf []byte) ([]byte, error) {
+	if !es.started {
+		es.started = true
+		pr, pw := io.Pipe()
+		es.pr = pr
+		go func() {
+			pw.CloseWithError(encoding.NewEncoder(pw).Encode(es.obj))
+		}()
+	}
+	n, err := es.pr.Read(buf)
+	return buf[:n], err
+}
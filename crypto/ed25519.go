@@ -0,0 +1,55 @@
+package crypto
+
+import (
+	"github.com/NebulousLabs/ed25519"
+)
+
+// ed25519Algorithm implements algorithm using the original Sia signing
+// scheme. It is the default algorithm, and the only one understood by
+// Sia nodes that predate algorithm-agile signing.
+type ed25519Algorithm struct{}
+
+// generateKeyPair implements algorithm.
+func (ed25519Algorithm) generateKeyPair() (sk, pk []byte, err error) {
+	skArr, pkArr, err := stdKeyGen.generate()
+	if err != nil {
+		return nil, nil, err
+	}
+	return skArr[:], pkArr[:], nil
+}
+
+// publicKey implements algorithm.
+func (ed25519Algorithm) publicKey(sk []byte) (pk []byte, err error) {
+	if len(sk) != SecretKeySize {
+		return nil, errInvalidSignature
+	}
+	pk = make([]byte, PublicKeySize)
+	copy(pk, sk[SecretKeySize-PublicKeySize:])
+	return pk, nil
+}
+
+// sign implements algorithm.
+func (ed25519Algorithm) sign(sk []byte, hash Hash) (sig []byte, err error) {
+	if len(sk) != SecretKeySize {
+		return nil, errInvalidSignature
+	}
+	var skNorm [SecretKeySize]byte
+	copy(skNorm[:], sk)
+	sigArr := ed25519.Sign(&skNorm, hash[:])
+	return sigArr[:], nil
+}
+
+// verify implements algorithm.
+func (ed25519Algorithm) verify(pk []byte, hash Hash, sig []byte) error {
+	if len(pk) != PublicKeySize || len(sig) != SignatureSize {
+		return errInvalidSignature
+	}
+	var pkNorm [PublicKeySize]byte
+	var sigNorm [SignatureSize]byte
+	copy(pkNorm[:], pk)
+	copy(sigNorm[:], sig)
+	if !ed25519.Verify(&pkNorm, hash[:], &sigNorm) {
+		return errInvalidSignature
+	}
+	return nil
+}
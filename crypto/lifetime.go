@@ -0,0 +1,96 @@
+package crypto
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/NebulousLabs/Sia/encoding"
+)
+
+// errSignatureExpired is returned by ReadSignedObjectWithClock and
+// VerifyObjectAt when a signed object's lifetime has elapsed.
+var errSignatureExpired = errors.New("signed object has expired")
+
+// signedEnvelope wraps a signed object with the creation time and
+// lifetime that WriteSignedObjectWithLifetime authenticates alongside
+// it. LifetimeSecs of 0 means the payload never expires, matching the
+// behavior of the lifetime-less WriteSignedObject.
+type signedEnvelope struct {
+	CreationTime int64
+	LifetimeSecs uint32
+	Payload      []byte
+}
+
+// expired reports whether the envelope's lifetime has elapsed as of now.
+func (e signedEnvelope) expired(now time.Time) bool {
+	if e.LifetimeSecs == 0 {
+		return false
+	}
+	return now.Unix() > e.CreationTime+int64(e.LifetimeSecs)
+}
+
+// WriteSignedObjectWithLifetime writes a length-prefixed object,
+// together with a creation time and lifetime, followed by a signature
+// covering all three. A verifier using ReadSignedObjectWithClock rejects
+// the object with errSignatureExpired once lifetimeSecs have elapsed
+// since it was written. lifetimeSecs of 0 means the object never
+// expires, the same as WriteSignedObject.
+func WriteSignedObjectWithLifetime(w io.Writer, obj interface{}, sk SecretKey, lifetimeSecs uint32) error {
+	env := signedEnvelope{
+		CreationTime: time.Now().Unix(),
+		LifetimeSecs: lifetimeSecs,
+		Payload:      encoding.Marshal(obj),
+	}
+	encEnv := encoding.Marshal(env)
+	sig, err := SignHash(HashBytes(encEnv), sk)
+	if err != nil {
+		return err
+	}
+	return encoding.NewEncoder(w).EncodeAll(encEnv, sig)
+}
+
+// ReadSignedObjectWithClock reads an object written by
+// WriteSignedObjectWithLifetime, verifies its signature, and rejects it
+// with errSignatureExpired if now is past its creation time plus
+// lifetime.
+func ReadSignedObjectWithClock(r io.Reader, obj interface{}, maxLen uint64, pk PublicKey, now time.Time) error {
+	// sig.Algo is seeded from pk so Signature.UnmarshalSia knows which
+	// wire layout to expect; see ReadSignedObject.
+	var encEnv []byte
+	sig := Signature{Algo: pk.Algo}
+	if err := encoding.NewDecoder(r).DecodeAll(&encEnv, &sig); err != nil {
+		return err
+	}
+	if err := VerifyHash(HashBytes(encEnv), pk, sig); err != nil {
+		return err
+	}
+	var env signedEnvelope
+	if err := encoding.Unmarshal(encEnv, &env); err != nil {
+		return err
+	}
+	if env.expired(now) {
+		return errSignatureExpired
+	}
+	return encoding.Unmarshal(env.Payload, obj)
+}
+
+// SignObjectWithLifetime encodes an object, its creation time and
+// lifetime, and a signature covering all three.
+func SignObjectWithLifetime(obj interface{}, sk SecretKey, lifetimeSecs uint32) ([]byte, error) {
+	b := new(bytes.Buffer)
+	if err := WriteSignedObjectWithLifetime(b, obj, sk, lifetimeSecs); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// VerifyObjectAt decodes an object written by SignObjectWithLifetime,
+// verifies its signature, and rejects it with errSignatureExpired if now
+// is past its creation time plus lifetime.
+func VerifyObjectAt(data []byte, obj interface{}, pk PublicKey, now time.Time) error {
+	r := bytes.NewReader(data)
+	// since object is already in memory, no need to enforce a maxLen
+	return ReadSignedObjectWithClock(r, obj, ^uint64(0), pk, now)
+}
@@ -0,0 +1,72 @@
+package crypto
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSignObjectWithLifetimeValid verifies that an object signed with a
+// lifetime verifies normally before that lifetime has elapsed.
+func TestSignObjectWithLifetimeValid(t *testing.T) {
+	sk, pk, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := SignObjectWithLifetime("hello", sk, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded string
+	now := time.Unix(time.Now().Unix(), 0)
+	if err := VerifyObjectAt(data, &decoded, pk, now); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != "hello" {
+		t.Fatalf("got %q, want %q", decoded, "hello")
+	}
+}
+
+// TestSignObjectWithLifetimeExpired verifies that an object is rejected
+// with errSignatureExpired once its lifetime has elapsed, even though
+// its signature is otherwise valid.
+func TestSignObjectWithLifetimeExpired(t *testing.T) {
+	sk, pk, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := SignObjectWithLifetime("hello", sk, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded string
+	future := time.Unix(time.Now().Unix()+61, 0)
+	err = VerifyObjectAt(data, &decoded, pk, future)
+	if err != errSignatureExpired {
+		t.Fatalf("got err=%v, want errSignatureExpired", err)
+	}
+}
+
+// TestSignObjectWithLifetimeZeroNeverExpires verifies that
+// lifetimeSecs of 0 means the signed object never expires, matching
+// WriteSignedObject's lifetime-less behavior.
+func TestSignObjectWithLifetimeZeroNeverExpires(t *testing.T) {
+	sk, pk, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := SignObjectWithLifetime("hello", sk, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded string
+	farFuture := time.Unix(time.Now().Unix()+1e9, 0)
+	if err := VerifyObjectAt(data, &decoded, pk, farFuture); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,55 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+)
+
+// rsaKeyBits is the modulus size used when generating RSA key pairs.
+const rsaKeyBits = 2048
+
+// rsaAlgorithm implements algorithm using RSA PKCS#1 v1.5 signatures over
+// a Hash, the same digest format Sia already uses for ed25519. Keys are
+// encoded as PKCS#1, matching the standard library's x509 tooling.
+type rsaAlgorithm struct{}
+
+// generateKeyPair implements algorithm.
+func (rsaAlgorithm) generateKeyPair() (sk, pk []byte, err error) {
+	priv, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		return nil, nil, err
+	}
+	return x509.MarshalPKCS1PrivateKey(priv), x509.MarshalPKCS1PublicKey(&priv.PublicKey), nil
+}
+
+// publicKey implements algorithm.
+func (rsaAlgorithm) publicKey(sk []byte) (pk []byte, err error) {
+	priv, err := x509.ParsePKCS1PrivateKey(sk)
+	if err != nil {
+		return nil, err
+	}
+	return x509.MarshalPKCS1PublicKey(&priv.PublicKey), nil
+}
+
+// sign implements algorithm.
+func (rsaAlgorithm) sign(sk []byte, hash Hash) (sig []byte, err error) {
+	priv, err := x509.ParsePKCS1PrivateKey(sk)
+	if err != nil {
+		return nil, err
+	}
+	return rsa.SignPKCS1v15(rand.Reader, priv, crypto.SHA256, hash[:])
+}
+
+// verify implements algorithm.
+func (rsaAlgorithm) verify(pk []byte, hash Hash, sig []byte) error {
+	pub, err := x509.ParsePKCS1PublicKey(pk)
+	if err != nil {
+		return errInvalidSignature
+	}
+	if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, hash[:], sig); err != nil {
+		return errInvalidSignature
+	}
+	return nil
+}
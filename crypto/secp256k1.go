@@ -0,0 +1,52 @@
+package crypto
+
+import (
+	"github.com/btcsuite/btcd/btcec"
+)
+
+// ecdsaSecp256k1Algorithm implements algorithm using ECDSA over the
+// secp256k1 curve, the curve used by Bitcoin-style hardware wallets. It
+// lets a host, renter, or wallet sign Sia messages with a hardware-backed
+// key without Sia having to understand any Bitcoin-specific wire format.
+type ecdsaSecp256k1Algorithm struct{}
+
+// generateKeyPair implements algorithm.
+func (ecdsaSecp256k1Algorithm) generateKeyPair() (sk, pk []byte, err error) {
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		return nil, nil, err
+	}
+	return priv.Serialize(), priv.PubKey().SerializeCompressed(), nil
+}
+
+// publicKey implements algorithm.
+func (ecdsaSecp256k1Algorithm) publicKey(sk []byte) (pk []byte, err error) {
+	priv, _ := btcec.PrivKeyFromBytes(btcec.S256(), sk)
+	return priv.PubKey().SerializeCompressed(), nil
+}
+
+// sign implements algorithm.
+func (ecdsaSecp256k1Algorithm) sign(sk []byte, hash Hash) (sig []byte, err error) {
+	priv, _ := btcec.PrivKeyFromBytes(btcec.S256(), sk)
+	signature, err := priv.Sign(hash[:])
+	if err != nil {
+		return nil, err
+	}
+	return signature.Serialize(), nil
+}
+
+// verify implements algorithm.
+func (ecdsaSecp256k1Algorithm) verify(pk []byte, hash Hash, sig []byte) error {
+	pubKey, err := btcec.ParsePubKey(pk, btcec.S256())
+	if err != nil {
+		return errInvalidSignature
+	}
+	signature, err := btcec.ParseSignature(sig, btcec.S256())
+	if err != nil {
+		return errInvalidSignature
+	}
+	if !signature.Verify(hash[:], pubKey) {
+		return errInvalidSignature
+	}
+	return nil
+}
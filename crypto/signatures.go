@@ -14,77 +14,144 @@ const (
 	// cryptographic operations, in bytes.
 	EntropySize = ed25519.EntropySize
 
-	// PublicKeySize defines the size of public keys in bytes.
+	// PublicKeySize defines the size of an ed25519 public key in bytes.
 	PublicKeySize = ed25519.PublicKeySize
 
-	// SecretKeySize defines the size of secret keys in bytes.
+	// SecretKeySize defines the size of an ed25519 secret key in bytes.
 	SecretKeySize = ed25519.SecretKeySize
 
-	// SignatureSize defines the size of signatures in bytes.
+	// SignatureSize defines the size of an ed25519 signature in bytes.
 	SignatureSize = ed25519.SignatureSize
 )
 
 type (
-	// PublicKey is an object that can be used to verify signatures.
-	PublicKey [PublicKeySize]byte
+	// PublicKey is an object that can be used to verify signatures. Algo
+	// identifies which signature algorithm Key belongs to; Key is that
+	// algorithm's raw, algorithm-specific encoding of the public key.
+	//
+	// Unlike the fixed-size array this type replaced, PublicKey contains
+	// a slice and so is not comparable with == and can't be used as a map
+	// key; compare values with PublicKey.Equal instead.
+	PublicKey struct {
+		Algo SignatureAlgorithm
+		Key  []byte
+	}
 
-	// SecretKey can be used to sign data for the corresponding public key.
-	SecretKey [SecretKeySize]byte
+	// SecretKey can be used to sign data for the corresponding public
+	// key. Algo identifies which signature algorithm Key belongs to; Key
+	// is that algorithm's raw, algorithm-specific encoding of the secret
+	// key.
+	//
+	// Unlike the fixed-size array this type replaced, SecretKey contains
+	// a slice and so is not comparable with == and can't be used as a map
+	// key; compare values with SecretKey.Equal instead.
+	SecretKey struct {
+		Algo SignatureAlgorithm
+		Key  []byte
+	}
 
 	// Signature proves that data was signed by the owner of a particular
-	// public key's corresponding secret key.
-	Signature [SignatureSize]byte
+	// public key's corresponding secret key. Algo identifies which
+	// signature algorithm produced Sig, and must match the Algo of the
+	// PublicKey used to verify it.
+	//
+	// Unlike the fixed-size array this type replaced, Signature contains
+	// a slice and so is not comparable with == and can't be used as a map
+	// key; compare values with Signature.Equal instead.
+	Signature struct {
+		Algo SignatureAlgorithm
+		Sig  []byte
+	}
 )
 
 var (
 	// errInvalidSignature is returned if a signature is provided that does not
 	// match the data and public key.
 	errInvalidSignature = errors.New("invalid signature")
+
+	// errAlgoMismatch is returned when a Signature is verified against a
+	// PublicKey using a different signature algorithm.
+	errAlgoMismatch = errors.New("signature algorithm does not match public key algorithm")
 )
 
-// GenerateKeyPair creates a public-secret keypair that can be used to sign and verify
-// messages.
+// GenerateKeyPair creates an ed25519 public-secret keypair that can be
+// used to sign and verify messages. Use GenerateKeyPairAlgo to generate a
+// keypair for a different signature algorithm.
 func GenerateKeyPair() (sk SecretKey, pk PublicKey, err error) {
-	return stdKeyGen.generate()
+	return GenerateKeyPairAlgo(AlgoEd25519)
 }
 
-// GenerateKeyPairDeterministic generates keys deterministically using the input
-// entropy. The input entropy must be 32 bytes in length.
+// GenerateKeyPairAlgo creates a public-secret keypair using the specified
+// signature algorithm.
+func GenerateKeyPairAlgo(a SignatureAlgorithm) (sk SecretKey, pk PublicKey, err error) {
+	impl, err := algo(a)
+	if err != nil {
+		return SecretKey{}, PublicKey{}, err
+	}
+	skBytes, pkBytes, err := impl.generateKeyPair()
+	if err != nil {
+		return SecretKey{}, PublicKey{}, err
+	}
+	return SecretKey{Algo: a, Key: skBytes}, PublicKey{Algo: a, Key: pkBytes}, nil
+}
+
+// GenerateKeyPairDeterministic generates an ed25519 keypair deterministically
+// using the input entropy. The input entropy must be 32 bytes in length.
 func GenerateKeyPairDeterministic(entropy [EntropySize]byte) (SecretKey, PublicKey) {
-	return stdKeyGen.generateDeterministic(entropy)
+	skArr, pkArr := stdKeyGen.generateDeterministic(entropy)
+	return SecretKey{Algo: AlgoEd25519, Key: skArr[:]}, PublicKey{Algo: AlgoEd25519, Key: pkArr[:]}
 }
 
-// SignHash signs a message using a secret key.
+// SignHash signs a message using a secret key, dispatching on sk's
+// signature algorithm.
 func SignHash(data Hash, sk SecretKey) (sig Signature, err error) {
-	skNorm := [SecretKeySize]byte(sk)
-	sig = *ed25519.Sign(&skNorm, data[:])
-	return sig, nil
+	impl, err := algo(sk.Algo)
+	if err != nil {
+		return Signature{}, err
+	}
+	sigBytes, err := impl.sign(sk.Key, data)
+	if err != nil {
+		return Signature{}, err
+	}
+	return Signature{Algo: sk.Algo, Sig: sigBytes}, nil
 }
 
-// VerifyHash uses a public key and input data to verify a signature.
+// VerifyHash uses a public key and input data to verify a signature,
+// dispatching on pk's signature algorithm. It returns errAlgoMismatch if
+// sig was produced by a different algorithm than pk.
 func VerifyHash(data Hash, pk PublicKey, sig Signature) error {
-	pkNorm := [PublicKeySize]byte(pk)
-	sigNorm := [SignatureSize]byte(sig)
-	verifies := ed25519.Verify(&pkNorm, data[:], &sigNorm)
-	if !verifies {
-		return errInvalidSignature
+	if sig.Algo != pk.Algo {
+		return errAlgoMismatch
 	}
-	return nil
+	impl, err := algo(pk.Algo)
+	if err != nil {
+		return err
+	}
+	return impl.verify(pk.Key, data, sig.Sig)
 }
 
-// WriteSignedObject writes a length-prefixed object followed by its signature.
+// WriteSignedObject writes a length-prefixed object followed by its
+// signature. An ed25519 signature is written in the legacy untagged
+// layout; a signature from any other registered algorithm is written with
+// a 1-byte algorithm prefix (see marshalTaggedBytes).
 func WriteSignedObject(w io.Writer, obj interface{}, sk SecretKey) error {
 	encObj := encoding.Marshal(obj)
-	sig, _ := SignHash(HashBytes(encObj), sk) // no error possible
+	sig, err := SignHash(HashBytes(encObj), sk)
+	if err != nil {
+		return err
+	}
 	return encoding.NewEncoder(w).EncodeAll(encObj, sig)
 }
 
 // ReadSignedObject reads a length-prefixed object followed by its signature,
 // and verifies the signature.
 func ReadSignedObject(r io.Reader, obj interface{}, maxLen uint64, pk PublicKey) error {
-	// read the encoded object and signature
+	// read the encoded object and signature. sig.Algo is seeded from pk,
+	// the algorithm the caller is actually verifying against, so
+	// Signature.UnmarshalSia knows which wire layout to expect instead of
+	// assuming the legacy ed25519 default.
 	var encObj []byte
-	var sig Signature
+	sig := Signature{Algo: pk.Algo}
 	err := encoding.NewDecoder(r).DecodeAll(&encObj, &sig)
 	if err != nil {
 		return err
@@ -98,10 +165,12 @@ func ReadSignedObject(r io.Reader, obj interface{}, maxLen uint64, pk PublicKey)
 }
 
 // SignObject encodes an object and its signature.
-func SignObject(obj interface{}, sk SecretKey) []byte {
+func SignObject(obj interface{}, sk SecretKey) ([]byte, error) {
 	b := new(bytes.Buffer)
-	WriteSignedObject(b, obj, sk) // no error possible with bytes.Buffer
-	return b.Bytes()
+	if err := WriteSignedObject(b, obj, sk); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
 }
 
 // VerifyObject decodes an object and verifies its signature.
@@ -112,7 +181,137 @@ func VerifyObject(data []byte, obj interface{}, pk PublicKey) error {
 }
 
 // PublicKey returns the public key that corresponds to a secret key.
-func (sk SecretKey) PublicKey() (pk PublicKey) {
-	copy(pk[:], sk[SecretKeySize-PublicKeySize:])
-	return
+func (sk SecretKey) PublicKey() (pk PublicKey, err error) {
+	impl, err := algo(sk.Algo)
+	if err != nil {
+		return PublicKey{}, err
+	}
+	pkBytes, err := impl.publicKey(sk.Key)
+	if err != nil {
+		return PublicKey{}, err
+	}
+	return PublicKey{Algo: sk.Algo, Key: pkBytes}, nil
+}
+
+// Ed25519PublicKey wraps a legacy fixed-size ed25519 public key as a
+// PublicKey, for callers migrating from the pre-algorithm-agile API.
+func Ed25519PublicKey(pk [PublicKeySize]byte) PublicKey {
+	return PublicKey{Algo: AlgoEd25519, Key: pk[:]}
+}
+
+// Ed25519SecretKey wraps a legacy fixed-size ed25519 secret key as a
+// SecretKey, for callers migrating from the pre-algorithm-agile API.
+func Ed25519SecretKey(sk [SecretKeySize]byte) SecretKey {
+	return SecretKey{Algo: AlgoEd25519, Key: sk[:]}
+}
+
+// Ed25519Signature wraps a legacy fixed-size ed25519 signature as a
+// Signature, for callers migrating from the pre-algorithm-agile API.
+func Ed25519Signature(sig [SignatureSize]byte) Signature {
+	return Signature{Algo: AlgoEd25519, Sig: sig[:]}
+}
+
+// marshalTaggedBytes writes data in the algorithm-agile wire format used
+// by PublicKey, SecretKey, and Signature. When algo is AlgoEd25519, it
+// writes data as the bare fixed-size layout those types had before they
+// became algorithm-agile, with no tag or length prefix at all, so every
+// object signed with the default algorithm stays byte-for-byte compatible
+// with nodes that predate this package's other signature algorithms.
+// Every other algorithm writes a one-byte tag followed by a
+// length-prefixed payload, since there is no legacy layout to match.
+func marshalTaggedBytes(w io.Writer, algo SignatureAlgorithm, data []byte, legacySize int) error {
+	if algo == AlgoEd25519 {
+		if len(data) != legacySize {
+			return errInvalidSignature
+		}
+		_, err := w.Write(data)
+		return err
+	}
+	if _, err := w.Write([]byte{byte(algo)}); err != nil {
+		return err
+	}
+	return encoding.NewEncoder(w).Encode(data)
+}
+
+// unmarshalTaggedBytes is the read side of marshalTaggedBytes. The wire
+// itself carries no self-describing tag for the legacy layout, so rather
+// than guess from the bytes (which is ambiguous: any byte value can
+// legally start a legacy ed25519 key or signature), it trusts algo, the
+// value already set on the receiver before UnmarshalSia was called. A
+// freshly zero-valued PublicKey/SecretKey/Signature defaults algo to
+// AlgoEd25519, so legacy data decodes correctly with no caller changes.
+// Decoding a non-default algorithm requires the caller to already know
+// which algorithm it's reading — e.g. ReadSignedObject sets Signature's
+// expected algo from the PublicKey it's verifying against, since that's
+// supplied by the caller before any decoding happens.
+func unmarshalTaggedBytes(r io.Reader, algo SignatureAlgorithm, legacySize int) ([]byte, error) {
+	if algo == AlgoEd25519 {
+		data := make([]byte, legacySize)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+	var tag [1]byte
+	if _, err := io.ReadFull(r, tag[:]); err != nil {
+		return nil, err
+	}
+	if SignatureAlgorithm(tag[0]) != algo {
+		return nil, errAlgoMismatch
+	}
+	var data []byte
+	if err := encoding.NewDecoder(r).Decode(&data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// MarshalSia implements encoding.SiaMarshaler.
+func (pk PublicKey) MarshalSia(w io.Writer) error {
+	return marshalTaggedBytes(w, pk.Algo, pk.Key, PublicKeySize)
+}
+
+// UnmarshalSia implements encoding.SiaUnmarshaler. pk.Algo must already
+// hold the expected algorithm (the zero value, AlgoEd25519, is correct
+// for legacy callers and for any caller that hasn't heard of other
+// algorithms) — see unmarshalTaggedBytes.
+func (pk *PublicKey) UnmarshalSia(r io.Reader) error {
+	data, err := unmarshalTaggedBytes(r, pk.Algo, PublicKeySize)
+	if err != nil {
+		return err
+	}
+	pk.Key = data
+	return nil
+}
+
+// MarshalSia implements encoding.SiaMarshaler.
+func (sk SecretKey) MarshalSia(w io.Writer) error {
+	return marshalTaggedBytes(w, sk.Algo, sk.Key, SecretKeySize)
+}
+
+// UnmarshalSia implements encoding.SiaUnmarshaler. See PublicKey's
+// UnmarshalSia for how sk.Algo is used.
+func (sk *SecretKey) UnmarshalSia(r io.Reader) error {
+	data, err := unmarshalTaggedBytes(r, sk.Algo, SecretKeySize)
+	if err != nil {
+		return err
+	}
+	sk.Key = data
+	return nil
+}
+
+// MarshalSia implements encoding.SiaMarshaler.
+func (sig Signature) MarshalSia(w io.Writer) error {
+	return marshalTaggedBytes(w, sig.Algo, sig.Sig, SignatureSize)
+}
+
+// UnmarshalSia implements encoding.SiaUnmarshaler. See PublicKey's
+// UnmarshalSia for how sig.Algo is used.
+func (sig *Signature) UnmarshalSia(r io.Reader) error {
+	data, err := unmarshalTaggedBytes(r, sig.Algo, SignatureSize)
+	if err != nil {
+		return err
+	}
+	sig.Sig = data
+	return nil
 }
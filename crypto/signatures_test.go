@@ -0,0 +1,125 @@
+package crypto
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/NebulousLabs/Sia/encoding"
+)
+
+// legacyEd25519PublicKeyFixture is a byte-for-byte capture of how a
+// pre-algorithm-agile PublicKey ([PublicKeySize]byte) was written to the
+// wire: PublicKeySize raw bytes with no algorithm tag or length prefix.
+func legacyEd25519PublicKeyFixture() []byte {
+	fixture := make([]byte, PublicKeySize)
+	for i := range fixture {
+		fixture[i] = byte(i + 3)
+	}
+	return fixture
+}
+
+// legacyEd25519SignatureFixture is a byte-for-byte capture of how a
+// pre-algorithm-agile Signature ([SignatureSize]byte) was written to the
+// wire: SignatureSize raw bytes with no algorithm tag or length prefix.
+func legacyEd25519SignatureFixture() []byte {
+	fixture := make([]byte, SignatureSize)
+	for i := range fixture {
+		fixture[i] = byte(i + 7)
+	}
+	return fixture
+}
+
+// TestPublicKeyLegacyRoundTrip verifies that a PublicKey decodes a
+// pre-algorithm-agile wire fixture as AlgoEd25519, and re-encodes back to
+// the exact same bytes, so nodes on either side of the algorithm-agility
+// change can still exchange ed25519 keys.
+func TestPublicKeyLegacyRoundTrip(t *testing.T) {
+	fixture := legacyEd25519PublicKeyFixture()
+
+	var pk PublicKey
+	if err := encoding.Unmarshal(fixture, &pk); err != nil {
+		t.Fatal(err)
+	}
+	if pk.Algo != AlgoEd25519 {
+		t.Fatalf("expected AlgoEd25519, got %v", pk.Algo)
+	}
+	if !bytes.Equal(pk.Key, fixture) {
+		t.Fatalf("decoded key %x does not match fixture %x", pk.Key, fixture)
+	}
+
+	reencoded := encoding.Marshal(pk)
+	if !bytes.Equal(reencoded, fixture) {
+		t.Fatalf("re-encoded bytes %x do not match fixture %x", reencoded, fixture)
+	}
+}
+
+// TestSignatureLegacyRoundTrip is the Signature analogue of
+// TestPublicKeyLegacyRoundTrip.
+func TestSignatureLegacyRoundTrip(t *testing.T) {
+	fixture := legacyEd25519SignatureFixture()
+
+	var sig Signature
+	if err := encoding.Unmarshal(fixture, &sig); err != nil {
+		t.Fatal(err)
+	}
+	if sig.Algo != AlgoEd25519 {
+		t.Fatalf("expected AlgoEd25519, got %v", sig.Algo)
+	}
+	if !bytes.Equal(sig.Sig, fixture) {
+		t.Fatalf("decoded signature %x does not match fixture %x", sig.Sig, fixture)
+	}
+
+	reencoded := encoding.Marshal(sig)
+	if !bytes.Equal(reencoded, fixture) {
+		t.Fatalf("re-encoded bytes %x do not match fixture %x", reencoded, fixture)
+	}
+}
+
+// TestSignedObjectLegacyCompatible verifies that WriteSignedObject /
+// ReadSignedObject, the wire-level signing API most of the codebase
+// builds on, still round-trip an object signed with the default ed25519
+// algorithm. The untagged byte layout itself is checked by
+// TestSignatureLegacyRoundTrip.
+func TestSignedObjectLegacyCompatible(t *testing.T) {
+	sk, pk, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := SignObject("hello", sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded string
+	if err := VerifyObject(data, &decoded, pk); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != "hello" {
+		t.Fatalf("got %q, want %q", decoded, "hello")
+	}
+}
+
+// TestSignedObjectNonDefaultAlgo verifies that WriteSignedObject /
+// ReadSignedObject also round-trips a non-default-algorithm signature,
+// since VerifyObject supplies the expected algorithm (via pk) before the
+// signature is decoded rather than guessing it from the wire bytes.
+func TestSignedObjectNonDefaultAlgo(t *testing.T) {
+	sk, pk, err := GenerateKeyPairAlgo(AlgoRSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := SignObject("hello", sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded string
+	if err := VerifyObject(data, &decoded, pk); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != "hello" {
+		t.Fatalf("got %q, want %q", decoded, "hello")
+	}
+}
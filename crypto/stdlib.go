@@ -0,0 +1,145 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	stded25519 "crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"io"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+// Sign implements crypto.Signer, letting a SecretKey be used anywhere the
+// standard library expects one: generating a self-signed X.509
+// certificate for a host's TLS endpoint, building an ssh.Signer, and so
+// on. RSA and ECDSA keys sign msg as-is, the digest opts.HashFunc()
+// already reduced it to, since that's the contract callers like
+// x509.CreateCertificate and the ssh package rely on; ed25519 keys ignore
+// opts and hash msg with this package's own hash, matching every other
+// SignHash caller.
+func (sk SecretKey) Sign(rand io.Reader, msg []byte, opts crypto.SignerOpts) ([]byte, error) {
+	switch sk.Algo {
+	case AlgoRSA:
+		priv, err := x509.ParsePKCS1PrivateKey(sk.Key)
+		if err != nil {
+			return nil, err
+		}
+		return rsa.SignPKCS1v15(rand, priv, opts.HashFunc(), msg)
+	case AlgoECDSASecp256k1:
+		priv, _ := btcec.PrivKeyFromBytes(btcec.S256(), sk.Key)
+		return ecdsa.SignASN1(rand, (*ecdsa.PrivateKey)(priv), msg)
+	default:
+		sig, err := SignHash(HashBytes(msg), sk)
+		if err != nil {
+			return nil, err
+		}
+		return sig.Sig, nil
+	}
+}
+
+// Public implements crypto.Signer. It returns sk's public key translated
+// to the concrete stdlib type each algorithm's standard tooling expects
+// — *rsa.PublicKey, *ecdsa.PublicKey, or ed25519.PublicKey — since
+// x509.CreateCertificate and ssh.NewSignerFromSigner type-switch on those
+// types and reject this package's own PublicKey struct.
+func (sk SecretKey) Public() crypto.PublicKey {
+	switch sk.Algo {
+	case AlgoRSA:
+		priv, err := x509.ParsePKCS1PrivateKey(sk.Key)
+		if err != nil {
+			return nil
+		}
+		return &priv.PublicKey
+	case AlgoECDSASecp256k1:
+		priv, _ := btcec.PrivKeyFromBytes(btcec.S256(), sk.Key)
+		return (*ecdsa.PublicKey)(priv.PubKey())
+	default:
+		pk, err := sk.PublicKey()
+		if err != nil {
+			return nil
+		}
+		return stded25519.PublicKey(pk.Key)
+	}
+}
+
+// Equal reports whether pk and x represent the same public key,
+// satisfying the crypto.PublicKey convention used by the standard
+// library since Go 1.15.
+func (pk PublicKey) Equal(x crypto.PublicKey) bool {
+	xpk, ok := x.(PublicKey)
+	if !ok {
+		return false
+	}
+	return pk.Algo == xpk.Algo && bytes.Equal(pk.Key, xpk.Key)
+}
+
+// Equal reports whether sk and x hold the same algorithm and key bytes.
+// SecretKey contains a slice and so is not comparable with ==, and can't
+// be used as a map key; use Equal instead.
+func (sk SecretKey) Equal(x SecretKey) bool {
+	return sk.Algo == x.Algo && bytes.Equal(sk.Key, x.Key)
+}
+
+// Equal reports whether sig and x hold the same algorithm and signature
+// bytes. Signature contains a slice and so is not comparable with ==,
+// and can't be used as a map key; use Equal instead.
+func (sig Signature) Equal(x Signature) bool {
+	return sig.Algo == x.Algo && bytes.Equal(sig.Sig, x.Sig)
+}
+
+// oidSiaSecretKey identifies a Sia SecretKey inside a PKCS#8
+// PrivateKeyInfo. It is a private-arc OID, since Sia signature
+// algorithms have no IANA-assigned identifier of their own.
+var oidSiaSecretKey = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 54321, 1, 1}
+
+// pkcs8 mirrors the ASN.1 PrivateKeyInfo structure defined in RFC 5208.
+type pkcs8 struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+// pkcs8SecretKeyBytes is the ASN.1 payload carried in a pkcs8.PrivateKey
+// field for a Sia SecretKey. Algo is an int because encoding/asn1 cannot
+// marshal unsigned or byte-kind fields such as SignatureAlgorithm.
+type pkcs8SecretKeyBytes struct {
+	Algo int
+	Key  []byte
+}
+
+// MarshalPKCS8 encodes sk as an ASN.1 DER PKCS#8 PrivateKeyInfo, so it
+// can be written out with pem.Encode and handled by tooling built around
+// standard PKI key files.
+func (sk SecretKey) MarshalPKCS8() ([]byte, error) {
+	inner, err := asn1.Marshal(pkcs8SecretKeyBytes{Algo: int(sk.Algo), Key: sk.Key})
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(pkcs8{
+		Version:    0,
+		Algo:       pkix.AlgorithmIdentifier{Algorithm: oidSiaSecretKey},
+		PrivateKey: inner,
+	})
+}
+
+// ParsePKCS8SiaKey parses a PKCS#8-encoded Sia SecretKey previously
+// produced by MarshalPKCS8.
+func ParsePKCS8SiaKey(der []byte) (SecretKey, error) {
+	var p pkcs8
+	if _, err := asn1.Unmarshal(der, &p); err != nil {
+		return SecretKey{}, err
+	}
+	if !p.Algo.Algorithm.Equal(oidSiaSecretKey) {
+		return SecretKey{}, errInvalidSignature
+	}
+	var inner pkcs8SecretKeyBytes
+	if _, err := asn1.Unmarshal(p.PrivateKey, &inner); err != nil {
+		return SecretKey{}, err
+	}
+	return SecretKey{Algo: SignatureAlgorithm(inner.Algo), Key: inner.Key}, nil
+}
@@ -0,0 +1,76 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	stded25519 "crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"testing"
+)
+
+// TestSecretKeySignRSA verifies that an RSA SecretKey, used as a
+// crypto.Signer the way x509.CreateCertificate would, produces a
+// signature a standards-compliant verifier accepts: signing a
+// pre-hashed digest under the hash opts names, not under some hash this
+// package chooses on its own.
+func TestSecretKeySignRSA(t *testing.T) {
+	sk, _, err := GenerateKeyPairAlgo(AlgoRSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, ok := sk.Public().(*rsa.PublicKey)
+	if !ok {
+		t.Fatalf("Public() returned %T, want *rsa.PublicKey", sk.Public())
+	}
+
+	digest := sha256.Sum256([]byte("hello"))
+	sig, err := sk.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig); err != nil {
+		t.Fatalf("stdlib rsa.VerifyPKCS1v15 rejected signature: %v", err)
+	}
+}
+
+// TestSecretKeySignECDSA is the secp256k1-ECDSA analogue of
+// TestSecretKeySignRSA.
+func TestSecretKeySignECDSA(t *testing.T) {
+	sk, _, err := GenerateKeyPairAlgo(AlgoECDSASecp256k1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, ok := sk.Public().(*ecdsa.PublicKey)
+	if !ok {
+		t.Fatalf("Public() returned %T, want *ecdsa.PublicKey", sk.Public())
+	}
+
+	digest := sha256.Sum256([]byte("hello"))
+	sig, err := sk.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ecdsa.VerifyASN1(pub, digest[:], sig) {
+		t.Fatal("stdlib ecdsa.VerifyASN1 rejected signature")
+	}
+}
+
+// TestSecretKeyPublicEd25519 verifies that an ed25519 SecretKey's Public
+// translates to the concrete stdlib ed25519.PublicKey type, not this
+// package's own PublicKey struct.
+func TestSecretKeyPublicEd25519(t *testing.T) {
+	sk, pk, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, ok := sk.Public().(stded25519.PublicKey)
+	if !ok {
+		t.Fatalf("Public() returned %T, want ed25519.PublicKey", sk.Public())
+	}
+	if !bytes.Equal(pub, pk.Key) {
+		t.Fatal("Public() key bytes do not match SecretKey.PublicKey()")
+	}
+}